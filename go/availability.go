@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateRange is an inclusive range of calendar dates, used for time-off
+// requests. The weekly schedule itself still runs on time.Weekday, so a
+// DateRange is only meaningful once it's anchored to a concrete week (see
+// the rolling-calendar work this unblocks); for now TimeOff is recorded
+// but not cross-checked against the weekday grid.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether date falls within the range, inclusive of both ends.
+func (r DateRange) Contains(date time.Time) bool {
+	d := date.Truncate(24 * time.Hour)
+	from := r.From.Truncate(24 * time.Hour)
+	to := r.To.Truncate(24 * time.Hour)
+	return !d.Before(from) && !d.After(to)
+}
+
+// RequestTimeOff records an approved PTO date range for the named employee.
+// As noted on DateRange, this is bookkeeping only - nothing in the
+// scheduling pipeline checks TimeOff yet, so it does not block an
+// assignment during that range.
+func (s *Scheduler) RequestTimeOff(employeeName string, from, to time.Time) error {
+	employee := s.employeeByName(employeeName)
+	if employee == nil {
+		return fmt.Errorf("employee %q not found", employeeName)
+	}
+	employee.TimeOff = append(employee.TimeOff, DateRange{From: from, To: to})
+	return nil
+}
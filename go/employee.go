@@ -12,31 +12,91 @@ const (
 	Morning   Shift = "Morning"
 	Afternoon Shift = "Afternoon"
 	Evening   Shift = "Evening"
+
+	// Night is a 24-hour "stay" shift. It is never offered as a standalone
+	// preference; it's placed as part of a ShiftPattern alongside the
+	// Recovery day it implies.
+	Night Shift = "Night"
+	// Recovery marks a day an employee is unavailable because they worked
+	// a Night shift the day before. It counts against DaysWorked like any
+	// other assignment so the weekly cap still applies.
+	Recovery Shift = "Recovery"
 )
 
 // Scheduling constraints
 const (
-	MinEmployeesPerShift = 2 // Minimum employees required per shift
-	MaxEmployeesPerShift = 8 // Maximum employees allowed per shift
-	MaxWorkDaysPerWeek   = 5 // Maximum days an employee can work per week
+	MinEmployeesPerShift      = 2 // Minimum employees required per shift
+	MaxEmployeesPerShift      = 8 // Maximum employees allowed per shift
+	MaxWorkDaysPerWeek        = 5 // Maximum days an employee can work per week
+	DefaultMaxConsecutiveDays = 5 // Default cap on consecutive worked days, used when Employee.MaxConsecutiveDays is unset
 )
 
 // Employee represents an individual employee with their preferences and assigned schedule
 type Employee struct {
-	Name       string                 // Employee's name
-	Preference map[time.Weekday]Shift // Preferred shifts for each day
-	Schedule   map[time.Weekday]Shift // Assigned shifts for each day
-	DaysWorked int                    // Number of days currently assigned
+	Name               string                      // Employee's name
+	Preference         map[time.Weekday]Shift      // Preferred shifts for each day (soft - "would rather")
+	Unavailable        map[time.Weekday][]Shift    // Shifts the employee can never work (hard - "can't")
+	TimeOff            []DateRange                 // Approved PTO date ranges
+	Schedule           map[time.Weekday]Shift      // Assigned shifts for each day
+	ShiftLocation      map[time.Weekday]LocationID // Location assigned for each day
+	DaysWorked         int                         // Number of days currently assigned
+	PreferredLocations []LocationID                // Locations this employee prefers, in priority order
+	LocationPenalty    map[LocationID]float64      // Extra cost of being borrowed to a non-primary location
+	PreferredPattern   map[time.Weekday]string     // Name of a registered ShiftPattern to place starting on a day
+	MaxConsecutiveDays int                         // Longest run of consecutive worked days allowed; 0 means DefaultMaxConsecutiveDays
+	Skills             []Role                      // Roles this employee can fill; empty means DefaultRole only
 }
 
 // NewEmployee creates a new employee with empty preferences and schedule
 func NewEmployee(name string) *Employee {
 	return &Employee{
-		Name:       name,
-		Preference: make(map[time.Weekday]Shift),
-		Schedule:   make(map[time.Weekday]Shift),
-		DaysWorked: 0,
+		Name:               name,
+		Preference:         make(map[time.Weekday]Shift),
+		Unavailable:        make(map[time.Weekday][]Shift),
+		TimeOff:            []DateRange{},
+		Schedule:           make(map[time.Weekday]Shift),
+		ShiftLocation:      make(map[time.Weekday]LocationID),
+		DaysWorked:         0,
+		PreferredLocations: []LocationID{},
+		LocationPenalty:    make(map[LocationID]float64),
+		PreferredPattern:   make(map[time.Weekday]string),
+		MaxConsecutiveDays: DefaultMaxConsecutiveDays,
+		Skills:             []Role{},
+	}
+}
+
+// HasSkill reports whether the employee can fill role. An employee with no
+// configured Skills is treated as able to fill only DefaultRole, so
+// pre-existing employees keep working against unconfigured (DefaultRole-only)
+// shift requirements without any extra setup.
+func (e *Employee) HasSkill(role Role) bool {
+	if len(e.Skills) == 0 {
+		return role == DefaultRole
+	}
+	for _, skill := range e.Skills {
+		if skill == role {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryLocation returns the employee's first preferred location, or ""
+// if they have none (in which case no location is treated as primary).
+func (e *Employee) PrimaryLocation() LocationID {
+	if len(e.PreferredLocations) == 0 {
+		return ""
+	}
+	return e.PreferredLocations[0]
+}
+
+// PenaltyFor returns the configured cost of assigning this employee to
+// location, 0 for their primary location or when no penalty is configured.
+func (e *Employee) PenaltyFor(location LocationID) float64 {
+	if location == e.PrimaryLocation() {
+		return 0
 	}
+	return e.LocationPenalty[location]
 }
 
 // SetPreference sets the preferred shift for a specific day
@@ -50,6 +110,12 @@ func (e *Employee) GetPreference(day time.Weekday) (Shift, bool) {
 	return shift, exists
 }
 
+// SetPreferredPattern records that the employee wants a registered
+// ShiftPattern (by name) placed starting on day, instead of a plain shift.
+func (e *Employee) SetPreferredPattern(day time.Weekday, patternName string) {
+	e.PreferredPattern[day] = patternName
+}
+
 // CanWorkDay checks if employee is available to work on a given day
 func (e *Employee) CanWorkDay(day time.Weekday) bool {
 	// Can't work if already assigned a shift that day
@@ -60,12 +126,46 @@ func (e *Employee) CanWorkDay(day time.Weekday) bool {
 	return e.DaysWorked < MaxWorkDaysPerWeek
 }
 
-// AssignShift assigns a shift to the employee for a specific day
-func (e *Employee) AssignShift(day time.Weekday, shift Shift) bool {
+// CanWorkShift checks whether the employee can be assigned a specific shift
+// on day: CanWorkDay must hold, and shift must not be blocked by a standing
+// Unavailable entry. This is the hard-availability check; it says nothing
+// about whether the employee would prefer a different shift.
+func (e *Employee) CanWorkShift(day time.Weekday, shift Shift) bool {
+	if !e.CanWorkDay(day) {
+		return false
+	}
+	return !e.IsUnavailable(day, shift)
+}
+
+// IsUnavailable reports whether shift is blocked for the employee on day.
+func (e *Employee) IsUnavailable(day time.Weekday, shift Shift) bool {
+	for _, blocked := range e.Unavailable[day] {
+		if blocked == shift {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUnavailable marks shift as one the employee can never work on day,
+// e.g. because of a standing commitment outside work.
+func (e *Employee) SetUnavailable(day time.Weekday, shift Shift) {
+	if e.IsUnavailable(day, shift) {
+		return
+	}
+	e.Unavailable[day] = append(e.Unavailable[day], shift)
+}
+
+// AssignShift assigns a shift at the given location to the employee for a
+// specific day. An employee can only hold one shift (and therefore one
+// location) per day, which is what makes the schedule map keyed by day
+// alone sufficient to forbid working two locations on the same day.
+func (e *Employee) AssignShift(day time.Weekday, shift Shift, location LocationID) bool {
 	if !e.CanWorkDay(day) {
 		return false
 	}
 	e.Schedule[day] = shift
+	e.ShiftLocation[day] = location
 	e.DaysWorked++
 	return true
 }
@@ -74,12 +174,20 @@ func (e *Employee) AssignShift(day time.Weekday, shift Shift) bool {
 func (e *Employee) RemoveShift(day time.Weekday) bool {
 	if _, exists := e.Schedule[day]; exists {
 		delete(e.Schedule, day)
+		delete(e.ShiftLocation, day)
 		e.DaysWorked--
 		return true
 	}
 	return false
 }
 
+// GetAssignedLocation returns the location the employee is working on a
+// given day, if any.
+func (e *Employee) GetAssignedLocation(day time.Weekday) (LocationID, bool) {
+	location, exists := e.ShiftLocation[day]
+	return location, exists
+}
+
 // GetAssignedShift returns the assigned shift for a given day
 func (e *Employee) GetAssignedShift(day time.Weekday) (Shift, bool) {
 	shift, exists := e.Schedule[day]
@@ -96,6 +204,7 @@ func (e *Employee) HasPreferenceMatch(day time.Weekday) bool {
 // ResetSchedule clears all shift assignments
 func (e *Employee) ResetSchedule() {
 	e.Schedule = make(map[time.Weekday]Shift)
+	e.ShiftLocation = make(map[time.Weekday]LocationID)
 	e.DaysWorked = 0
 }
 
@@ -116,14 +225,18 @@ func (e *Employee) GetWorkSummary() string {
 		if assignedShift, hasAssignment := e.GetAssignedShift(day); hasAssignment {
 			hasAssignments = true
 			shiftIcon := getShiftIcon(assignedShift)
+			locationTag := ""
+			if location, hasLocation := e.GetAssignedLocation(day); hasLocation {
+				locationTag = fmt.Sprintf(" @ %s", location)
+			}
 
 			// Check if preference was matched
 			if e.HasPreferenceMatch(day) {
-				summary += fmt.Sprintf("   %s %-10s → %s ✨ PREFERRED\n", shiftIcon, day, assignedShift)
+				summary += fmt.Sprintf("   %s %-10s → %s%s ✨ PREFERRED\n", shiftIcon, day, assignedShift, locationTag)
 			} else if preferredShift, hasPreference := e.GetPreference(day); hasPreference {
-				summary += fmt.Sprintf("   %s %-10s → %s (wanted %s)\n", shiftIcon, day, assignedShift, preferredShift)
+				summary += fmt.Sprintf("   %s %-10s → %s%s (wanted %s)\n", shiftIcon, day, assignedShift, locationTag, preferredShift)
 			} else {
-				summary += fmt.Sprintf("   %s %-10s → %s\n", shiftIcon, day, assignedShift)
+				summary += fmt.Sprintf("   %s %-10s → %s%s\n", shiftIcon, day, assignedShift, locationTag)
 			}
 		}
 	}
@@ -0,0 +1,45 @@
+package main
+
+// LocationID uniquely identifies a Location within the scheduler.
+type LocationID string
+
+// DefaultLocationID is the location every Scheduler starts with so that
+// single-site callers (and the ILP optimizer, which does not yet reason
+// about multiple locations) keep working without any setup.
+const DefaultLocationID LocationID = "default"
+
+// Location represents a single site (e.g. a store or branch) that needs
+// its own staffing coverage, independent of every other location in the
+// system.
+type Location struct {
+	ID          LocationID
+	Name        string
+	MinPerShift int
+	MaxPerShift int
+	// Requirements is the per-shift role quota for this location, e.g.
+	// {Morning: {Manager: 1, Cashier: 2}}. A shift absent from this map
+	// falls back to {DefaultRole: MinPerShift}, so locations that never
+	// configure roles behave exactly as before role support existed.
+	Requirements map[Shift]map[Role]int
+}
+
+// NewLocation creates a Location with the given staffing bounds.
+func NewLocation(id LocationID, name string, minPerShift, maxPerShift int) *Location {
+	return &Location{
+		ID:           id,
+		Name:         name,
+		MinPerShift:  minPerShift,
+		MaxPerShift:  maxPerShift,
+		Requirements: make(map[Shift]map[Role]int),
+	}
+}
+
+// RequirementsFor returns the role quota for shift: the configured
+// requirements if any were set, otherwise a single DefaultRole bucket
+// sized to MinPerShift.
+func (l *Location) RequirementsFor(shift Shift) map[Role]int {
+	if reqs, ok := l.Requirements[shift]; ok && len(reqs) > 0 {
+		return reqs
+	}
+	return map[Role]int{DefaultRole: l.MinPerShift}
+}
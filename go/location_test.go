@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMissingRolesReportsGapEvenWhenHeadcountIsMet is the request's headline
+// behavior: a shift with two Cashiers and no Manager is still understaffed,
+// even though its raw headcount already meets MinPerShift. This is what
+// configureShiftRequirements (main.go) lets a user actually trigger, rather
+// than only code written directly against Location.Requirements.
+func TestMissingRolesReportsGapEvenWhenHeadcountIsMet(t *testing.T) {
+	s := NewScheduler()
+	location := s.getLocation(DefaultLocationID)
+	location.Requirements = map[Shift]map[Role]int{
+		Morning: {"Manager": 1, "Cashier": 2},
+	}
+
+	cashier1 := NewEmployee("Casey")
+	cashier1.Skills = []Role{"Cashier"}
+	cashier2 := NewEmployee("Riley")
+	cashier2.Skills = []Role{"Cashier"}
+	s.Employees = append(s.Employees, cashier1, cashier2)
+
+	s.assign(cashier1, time.Monday, Morning, DefaultLocationID)
+	s.assign(cashier2, time.Monday, Morning, DefaultLocationID)
+
+	missing := s.missingRoles(location, time.Monday, Morning)
+	if missing["Cashier"] != 0 {
+		t.Errorf("expected Cashier requirement to be met, got missing=%v", missing)
+	}
+	if missing["Manager"] != 1 {
+		t.Errorf("expected 1 missing Manager despite headcount being met, got missing=%v", missing)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // main function runs the employee scheduling application
@@ -24,7 +26,13 @@ func main() {
 		fmt.Println("1. Add Employee")
 		fmt.Println("2. Generate Schedule")
 		fmt.Println("3. View Schedule")
-		fmt.Println("4. Exit")
+		fmt.Println("4. Set Time Off")
+		fmt.Println("5. Save Schedule")
+		fmt.Println("6. Load Schedule")
+		fmt.Println("7. Export Schedule")
+		fmt.Println("8. Add Location")
+		fmt.Println("9. Configure Shift Requirements")
+		fmt.Println("10. Exit")
 		fmt.Print("Choose an option: ")
 
 		scanner.Scan()
@@ -45,6 +53,18 @@ func main() {
 		case "3":
 			scheduler.PrintSchedule()
 		case "4":
+			setTimeOff(scheduler, scanner)
+		case "5":
+			saveSchedule(scheduler, scanner)
+		case "6":
+			loadSchedule(scheduler, scanner)
+		case "7":
+			exportSchedule(scheduler, scanner)
+		case "8":
+			addLocation(scheduler, scanner)
+		case "9":
+			configureShiftRequirements(scheduler, scanner)
+		case "10":
 			fmt.Println("Goodbye!")
 			return
 		default:
@@ -52,3 +72,193 @@ func main() {
 		}
 	}
 }
+
+// setTimeOff collects an employee name and a date range, then records it as
+// approved PTO via Scheduler.RequestTimeOff.
+func setTimeOff(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Print("\n👤 Employee name: ")
+	scanner.Scan()
+	name := strings.TrimSpace(scanner.Text())
+
+	fmt.Print("📅 From date (YYYY-MM-DD): ")
+	scanner.Scan()
+	from, err := time.Parse("2006-01-02", strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		fmt.Println("❌ Invalid date.")
+		return
+	}
+
+	fmt.Print("📅 To date (YYYY-MM-DD): ")
+	scanner.Scan()
+	to, err := time.Parse("2006-01-02", strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		fmt.Println("❌ Invalid date.")
+		return
+	}
+
+	if err := scheduler.RequestTimeOff(name, from, to); err != nil {
+		fmt.Printf("❌ %s\n", err)
+		return
+	}
+	fmt.Printf("✅ Time off recorded for %s: %s to %s\n", name, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	fmt.Println("⚠️  Note: time off is recorded but not yet enforced - Generate Schedule may still assign shifts in this range.")
+}
+
+// addLocation interactively registers a new site to be scheduled, so the
+// multi-location feature is reachable without writing code against
+// Scheduler.AddLocation directly.
+func addLocation(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Print("\n🏬 Location name: ")
+	scanner.Scan()
+	name := strings.TrimSpace(scanner.Text())
+	if name == "" {
+		fmt.Println("❌ Name cannot be empty!")
+		return
+	}
+
+	fmt.Printf("🔢 Minimum employees per shift (blank = %d): ", MinEmployeesPerShift)
+	scanner.Scan()
+	minInput := strings.TrimSpace(scanner.Text())
+	minPerShift := MinEmployeesPerShift
+	if minInput != "" {
+		parsed, err := strconv.Atoi(minInput)
+		if err != nil || parsed < 0 {
+			fmt.Println("❌ Invalid number.")
+			return
+		}
+		minPerShift = parsed
+	}
+
+	fmt.Printf("🔢 Maximum employees per shift (blank = %d): ", MaxEmployeesPerShift)
+	scanner.Scan()
+	maxInput := strings.TrimSpace(scanner.Text())
+	maxPerShift := MaxEmployeesPerShift
+	if maxInput != "" {
+		parsed, err := strconv.Atoi(maxInput)
+		if err != nil || parsed < minPerShift {
+			fmt.Println("❌ Invalid number.")
+			return
+		}
+		maxPerShift = parsed
+	}
+
+	id := LocationID(strings.ToLower(strings.ReplaceAll(name, " ", "-")))
+	scheduler.AddLocation(NewLocation(id, name, minPerShift, maxPerShift))
+	fmt.Printf("✅ Location '%s' added (%d locations total)\n", name, len(scheduler.Locations))
+	fmt.Println("💡 Use 'Configure Shift Requirements' to set per-role staffing quotas for it.")
+}
+
+// configureShiftRequirements lets the user pick a registered location (the
+// default one included) and set its per-shift role quotas, so the per-role
+// understaffing PrintSchedule/missingRoles reports can actually be
+// triggered from the CLI instead of only by code written directly against
+// Location.Requirements.
+func configureShiftRequirements(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Println("\n🏬 Locations:")
+	for i, loc := range scheduler.Locations {
+		fmt.Printf("   %d=%s\n", i, loc.Name)
+	}
+	fmt.Print("Choose a location number: ")
+	scanner.Scan()
+	index, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || index < 0 || index >= len(scheduler.Locations) {
+		fmt.Println("❌ Invalid location.")
+		return
+	}
+	location := scheduler.Locations[index]
+
+	for _, shift := range scheduler.Shifts {
+		fmt.Printf("   %s requirements (role:count, comma-separated, blank = skip): ", shift)
+		scanner.Scan()
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+
+		requirements := make(map[Role]int)
+		for _, token := range strings.Split(input, ",") {
+			parts := strings.SplitN(token, ":", 2)
+			if len(parts) != 2 {
+				fmt.Printf("   ❌ Skipping invalid entry %q (want role:count)\n", strings.TrimSpace(token))
+				continue
+			}
+			role := strings.TrimSpace(parts[0])
+			count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if role == "" || err != nil || count < 0 {
+				fmt.Printf("   ❌ Skipping invalid entry %q (want role:count)\n", strings.TrimSpace(token))
+				continue
+			}
+			requirements[Role(role)] = count
+		}
+
+		if len(requirements) > 0 {
+			location.Requirements[shift] = requirements
+			fmt.Printf("   ✅ %s requirements set: %s\n", shift, input)
+		}
+	}
+}
+
+// saveSchedule writes the scheduler's current state to a JSON file.
+func saveSchedule(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Print("\n💾 Save to path: ")
+	scanner.Scan()
+	path := strings.TrimSpace(scanner.Text())
+
+	if err := scheduler.SaveJSON(path); err != nil {
+		fmt.Printf("❌ Could not save: %s\n", err)
+		return
+	}
+	fmt.Printf("✅ Schedule saved to %s\n", path)
+}
+
+// loadSchedule replaces the scheduler's current state with a previously saved JSON file.
+func loadSchedule(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Print("\n📂 Load from path: ")
+	scanner.Scan()
+	path := strings.TrimSpace(scanner.Text())
+
+	if err := scheduler.LoadJSON(path); err != nil {
+		fmt.Printf("❌ Could not load: %s\n", err)
+		return
+	}
+	fmt.Printf("✅ Schedule loaded from %s\n", path)
+}
+
+// exportSchedule writes the current schedule as CSV or iCalendar, based on user choice.
+func exportSchedule(scheduler *Scheduler, scanner *bufio.Scanner) {
+	fmt.Print("\n📤 Export format (csv/ics): ")
+	scanner.Scan()
+	format := strings.ToLower(strings.TrimSpace(scanner.Text()))
+
+	fmt.Print("📤 Export to path: ")
+	scanner.Scan()
+	path := strings.TrimSpace(scanner.Text())
+
+	switch format {
+	case "csv":
+		if err := scheduler.ExportCSV(path); err != nil {
+			fmt.Printf("❌ Could not export: %s\n", err)
+			return
+		}
+	case "ics":
+		fmt.Print("📅 Week start date (YYYY-MM-DD): ")
+		scanner.Scan()
+		weekStart, err := time.Parse("2006-01-02", strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			fmt.Println("❌ Invalid date.")
+			return
+		}
+		shiftTimes := scheduler.ShiftTimes
+		if shiftTimes == nil {
+			shiftTimes = DefaultShiftTimes()
+		}
+		if err := scheduler.ExportICS(path, weekStart, shiftTimes); err != nil {
+			fmt.Printf("❌ Could not export: %s\n", err)
+			return
+		}
+	default:
+		fmt.Println("❌ Unknown format. Use 'csv' or 'ics'.")
+		return
+	}
+	fmt.Printf("✅ Schedule exported to %s\n", path)
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// SchedulerOptions configures the optional ILP-based optimizer used by
+// AssignShiftsOptimal. It exposes the weights/penalties of the underlying
+// 0/1 program so callers can tune fairness vs. staffing without touching
+// the solver itself.
+type SchedulerOptions struct {
+	// PreferenceWeight is the reward for assigning an employee to the shift
+	// they asked for on a given day. Keyed by employee name so individual
+	// employees can be weighted differently; employees absent from the map
+	// use DefaultPreferenceWeight.
+	PreferenceWeight map[string]float64
+
+	// DefaultPreferenceWeight is used for employees not present in
+	// PreferenceWeight.
+	DefaultPreferenceWeight float64
+
+	// UnmatchedPenalty (lambda) is subtracted once per preference that
+	// could not be honored.
+	UnmatchedPenalty float64
+
+	// UnderstaffPenalty (mu) is subtracted per unit of understaffing slack,
+	// i.e. per missing employee below MinEmployeesPerShift on a shift.
+	UnderstaffPenalty float64
+
+	// MaxSearchNodes bounds the branch-and-bound search. If the solver
+	// exhausts this budget without completing, AssignShiftsOptimal falls
+	// back to the existing greedy pipeline.
+	MaxSearchNodes int
+
+	// ShiftTimes overrides the default real-world clock times ExportICS
+	// maps each Shift onto. AssignShiftsOptimal copies this onto
+	// Scheduler.ShiftTimes, which exportSchedule (main.go) passes to
+	// ExportICS; a nil map (or a shift missing from it) falls back to
+	// DefaultShiftTimes.
+	ShiftTimes map[Shift]TimeRange
+}
+
+// DefaultSchedulerOptions returns sane defaults for the optimizer: preference
+// matching is rewarded, understaffing is penalized heavily enough that the
+// solver always prefers filling a shift over honoring a preference.
+func DefaultSchedulerOptions() SchedulerOptions {
+	return SchedulerOptions{
+		PreferenceWeight:        make(map[string]float64),
+		DefaultPreferenceWeight: 1.0,
+		UnmatchedPenalty:        0.5,
+		UnderstaffPenalty:       10.0,
+		MaxSearchNodes:          50000,
+		ShiftTimes:              DefaultShiftTimes(),
+	}
+}
+
+func (o SchedulerOptions) preferenceWeight(employeeName string) float64 {
+	if w, ok := o.PreferenceWeight[employeeName]; ok {
+		return w
+	}
+	return o.DefaultPreferenceWeight
+}
+
+// AssignShiftsOptimal solves the weekly schedule as a 0/1 integer program:
+// binary x[e,d,s] = 1 if employee e works day d shift s, subject to
+//  1. sum_s x[e,d,s] <= 1                               (one shift per day)
+//  2. sum_{d,s} x[e,d,s] <= MaxWorkDaysPerWeek           (weekly cap)
+//  3. x[e,d,s] = 0 whenever !employee.CanWorkShift(d,s)  (hard availability)
+//  4. x[e,d,s] = 0 whenever it would push the employee's consecutive-day
+//     run past MaxConsecutiveDays
+//  5. sum_e x[e,d,s] <= location.MaxPerShift, with understaffing against
+//     location.RequirementsFor(s) allowed as slack so the model degrades
+//     gracefully instead of failing outright
+//
+// The objective maximizes preference matches minus unmatched-preference and
+// per-role understaffing penalties. Each day is solved independently by
+// branch-and-bound over which employees staff which shift. This is NOT a
+// globally exact weekly solve: solving day-by-day has no way to know that
+// filling Monday-Friday exhaustively will starve the weekend, since each
+// day's branch-and-bound only sees "does this employee still have budget
+// left", not "which days should that budget be spent on". Left alone, that
+// lets the solver burn every employee's weekly days on the first days it
+// sees and leave later days fully unstaffed.
+//
+// To counter that, planWorkDays runs first and rotates which
+// MaxWorkDaysPerWeek-sized window of the week each employee is eligible for,
+// so solveDay never has access to an employee whose window has already
+// moved past (or not yet reached) that day. This spreads employees evenly
+// across the week; it is a heuristic fairness pass, not a proof of weekly
+// optimality.
+//
+// Per-day branch-and-bound is still exponential in the number of eligible
+// candidates (options^candidates), so opts.MaxSearchNodes remains a real
+// ceiling: past roughly a dozen eligible employees on a single day, the
+// search will exhaust its budget before finishing. If that happens,
+// AssignShiftsOptimal abandons the ILP for the whole week and falls back to
+// the existing greedy AssignShifts pipeline.
+//
+// Two things AssignShifts handles that this solver does NOT:
+//   - Multi-location scheduling: the optimizer only staffs DefaultLocationID;
+//     schedulers with additional locations should still use the greedy
+//     AssignShifts for those sites.
+//   - ShiftPattern placement (e.g. NightStayPattern): patterns are
+//     multi-day-atomic by construction, which doesn't fit a per-day solver.
+//     An employee with a PreferredPattern set is solved like any other
+//     employee on the pattern's individual days - the pattern itself is
+//     never placed, so use the greedy AssignShifts for schedulers relying on
+//     patterns.
+func (s *Scheduler) AssignShiftsOptimal(opts SchedulerOptions) {
+	s.resetSchedules()
+	s.ShiftTimes = opts.ShiftTimes
+
+	solver := &daySolver{
+		scheduler: s,
+		opts:      opts,
+		eligible:  s.planWorkDays(),
+		location:  s.getLocation(DefaultLocationID),
+	}
+
+	for _, day := range s.Days {
+		if !solver.solveDay(day) {
+			fmt.Println("⚠️  ILP optimizer exceeded its search budget; falling back to greedy assignment")
+			s.assignPreferredShifts()
+			s.ensureMinimumStaffing()
+			return
+		}
+	}
+}
+
+// planWorkDays decides, for every employee, which days of the week they are
+// eligible to be considered for by solveDay. Each employee gets a
+// MaxWorkDaysPerWeek-sized rolling window starting at an offset derived from
+// their position in s.Employees, so windows are staggered across the week
+// instead of every employee competing for the same early days. This is what
+// keeps the day-by-day solver from exhausting everyone's weekly budget on
+// Monday-Friday and leaving the weekend with no eligible candidates at all.
+func (s *Scheduler) planWorkDays() map[string]map[time.Weekday]bool {
+	eligible := make(map[string]map[time.Weekday]bool, len(s.Employees))
+	windowSize := MaxWorkDaysPerWeek
+	if windowSize > len(s.Days) {
+		windowSize = len(s.Days)
+	}
+
+	for i, employee := range s.Employees {
+		days := make(map[time.Weekday]bool, windowSize)
+		for offset := 0; offset < windowSize; offset++ {
+			day := s.Days[(i+offset)%len(s.Days)]
+			days[day] = true
+		}
+		eligible[employee.Name] = days
+	}
+	return eligible
+}
+
+// daySolver runs the per-day branch-and-bound search described above.
+type daySolver struct {
+	scheduler *Scheduler
+	opts      SchedulerOptions
+	eligible  map[string]map[time.Weekday]bool
+	location  *Location
+	nodes     int
+}
+
+// maxPerShift is the per-shift headcount cap the solver enforces, falling
+// back to the module default if DefaultLocationID somehow isn't registered.
+func (d *daySolver) maxPerShift() int {
+	if d.location != nil {
+		return d.location.MaxPerShift
+	}
+	return MaxEmployeesPerShift
+}
+
+// requirementsFor is the per-role staffing requirement the solver scores
+// understaffing against, mirroring Location.RequirementsFor's own fallback.
+func (d *daySolver) requirementsFor(shift Shift) map[Role]int {
+	if d.location != nil {
+		return d.location.RequirementsFor(shift)
+	}
+	return map[Role]int{DefaultRole: MinEmployeesPerShift}
+}
+
+// solveDay assigns every employee at most one shift on day, maximizing the
+// objective, and commits the result to both the employee and scheduler
+// state. It returns false if the node budget was exhausted first.
+func (d *daySolver) solveDay(day time.Weekday) bool {
+	candidates := make([]*Employee, 0, len(d.scheduler.Employees))
+	for _, e := range d.scheduler.Employees {
+		if e.CanWorkDay(day) && d.eligible[e.Name][day] {
+			candidates = append(candidates, e)
+		}
+	}
+
+	counts := make(map[Shift]int, len(d.scheduler.Shifts))
+	best := make(map[string]Shift, len(candidates))
+	bestScore := negInf
+
+	assignment := make(map[string]Shift, len(candidates))
+	ok := d.branch(day, candidates, 0, counts, assignment, &best, &bestScore)
+	if !ok {
+		return false
+	}
+
+	for name, shift := range best {
+		employee := d.scheduler.employeeByName(name)
+		if employee != nil {
+			d.scheduler.assign(employee, day, shift, DefaultLocationID)
+		}
+	}
+	return true
+}
+
+const negInf = -1e18
+
+// branch explores assigning candidates[idx:] to a shift (or leaving them
+// unassigned), tracking the best complete assignment seen so far.
+func (d *daySolver) branch(day time.Weekday, candidates []*Employee, idx int, counts map[Shift]int, assignment map[string]Shift, best *map[string]Shift, bestScore *float64) bool {
+	d.nodes++
+	if d.nodes > d.opts.MaxSearchNodes {
+		return false
+	}
+
+	if idx == len(candidates) {
+		score := d.score(day, assignment)
+		if score > *bestScore {
+			*bestScore = score
+			snapshot := make(map[string]Shift, len(assignment))
+			for k, v := range assignment {
+				snapshot[k] = v
+			}
+			*best = snapshot
+		}
+		return true
+	}
+
+	employee := candidates[idx]
+	options := append([]Shift{""}, d.scheduler.Shifts...) // "" means unassigned
+
+	maxConsecutive := employee.MaxConsecutiveDays
+	if maxConsecutive == 0 {
+		maxConsecutive = DefaultMaxConsecutiveDays
+	}
+
+	for _, shift := range options {
+		if shift != "" {
+			// Hard availability (Unavailable entries) and the consecutive-day
+			// cap are non-negotiable, same as canAssign enforces for the
+			// greedy pipeline - this branch must never consider a shift the
+			// employee can't legally be given.
+			if !employee.CanWorkShift(day, shift) {
+				continue
+			}
+			if d.scheduler.consecutiveDaysIfAssigned(employee, day) > maxConsecutive {
+				continue
+			}
+			if counts[shift] >= d.maxPerShift() {
+				continue
+			}
+			counts[shift]++
+			assignment[employee.Name] = shift
+		}
+
+		if !d.branch(day, candidates, idx+1, counts, assignment, best, bestScore) {
+			return false
+		}
+
+		if shift != "" {
+			counts[shift]--
+			delete(assignment, employee.Name)
+		}
+	}
+
+	return true
+}
+
+// score evaluates the objective contribution of a single day's assignment:
+// preference matches minus unmatched preferences minus per-role
+// understaffing slack, the same "gap per required role" notion
+// Scheduler.missingRoles uses for the greedy pipeline.
+func (d *daySolver) score(day time.Weekday, assignment map[string]Shift) float64 {
+	score := 0.0
+
+	roleCounts := make(map[Shift]map[Role]int, len(d.scheduler.Shifts))
+	for name, shift := range assignment {
+		employee := d.scheduler.employeeByName(name)
+		if employee == nil {
+			continue
+		}
+
+		weight := d.opts.preferenceWeight(name)
+		if preferred, hasPreference := employee.GetPreference(day); hasPreference {
+			if preferred == shift {
+				score += weight
+			} else {
+				score -= d.opts.UnmatchedPenalty
+			}
+		}
+
+		if roleCounts[shift] == nil {
+			roleCounts[shift] = make(map[Role]int)
+		}
+		if len(employee.Skills) == 0 {
+			roleCounts[shift][DefaultRole]++
+			continue
+		}
+		for _, skill := range employee.Skills {
+			roleCounts[shift][skill]++
+		}
+	}
+
+	for _, shift := range d.scheduler.Shifts {
+		for role, required := range d.requirementsFor(shift) {
+			if deficit := required - roleCounts[shift][role]; deficit > 0 {
+				score -= d.opts.UnderstaffPenalty * float64(deficit)
+			}
+		}
+	}
+
+	return score
+}
+
+// employeeByName looks up an employee by name; used by the solver to go
+// from the assignment map (keyed by name, for easy snapshotting) back to
+// the *Employee needed to commit an assignment.
+func (s *Scheduler) employeeByName(name string) *Employee {
+	for _, e := range s.Employees {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
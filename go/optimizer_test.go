@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newOptimizerTestScheduler(employeeCount int) *Scheduler {
+	s := NewScheduler()
+	for i := 0; i < employeeCount; i++ {
+		s.Employees = append(s.Employees, NewEmployee(fmt.Sprintf("Employee%d", i)))
+	}
+	return s
+}
+
+// TestAssignShiftsOptimalRespectsWeeklyCap covers the core invariant the ILP
+// is supposed to guarantee regardless of the day-spreading heuristic: no
+// employee is ever scheduled beyond MaxWorkDaysPerWeek.
+func TestAssignShiftsOptimalRespectsWeeklyCap(t *testing.T) {
+	s := newOptimizerTestScheduler(6)
+	s.AssignShiftsOptimal(DefaultSchedulerOptions())
+
+	for _, employee := range s.Employees {
+		if employee.DaysWorked > MaxWorkDaysPerWeek {
+			t.Errorf("%s worked %d days, exceeding the cap of %d", employee.Name, employee.DaysWorked, MaxWorkDaysPerWeek)
+		}
+	}
+}
+
+// TestAssignShiftsOptimalSpreadsAcrossWeek guards against the day-by-day
+// solver's blind spot: with no preferences at all, each day's
+// branch-and-bound is free to use every employee it sees, so without the
+// planWorkDays spreading pass it exhaustively fills Monday-Friday and leaves
+// no budget (and so no eligible candidates) for Saturday/Sunday.
+func TestAssignShiftsOptimalSpreadsAcrossWeek(t *testing.T) {
+	s := newOptimizerTestScheduler(6)
+	s.AssignShiftsOptimal(DefaultSchedulerOptions())
+
+	for _, day := range s.Days {
+		total := 0
+		for _, shift := range s.Shifts {
+			total += len(s.Schedule[DefaultLocationID][day][shift])
+		}
+		if total == 0 {
+			t.Errorf("expected %s to have at least one assignment, got 0 (day-by-day solve starved it)", day)
+		}
+	}
+}
+
+// TestAssignShiftsOptimalRespectsUnavailability reproduces the maintainer's
+// repro: every employee is marked Unavailable for every shift on Monday, so
+// the ILP must leave Monday completely unassigned rather than ignoring the
+// hard-availability constraint and filling it anyway.
+func TestAssignShiftsOptimalRespectsUnavailability(t *testing.T) {
+	s := newOptimizerTestScheduler(4)
+	for _, employee := range s.Employees {
+		for _, shift := range s.Shifts {
+			employee.SetUnavailable(time.Monday, shift)
+		}
+	}
+
+	s.AssignShiftsOptimal(DefaultSchedulerOptions())
+
+	for _, shift := range s.Shifts {
+		if assigned := s.Schedule[DefaultLocationID][time.Monday][shift]; len(assigned) != 0 {
+			t.Errorf("expected no one assigned to Monday %s, got %v", shift, assigned)
+		}
+	}
+}
+
+// TestAssignShiftsOptimalRespectsMaxConsecutiveDays covers the second gap in
+// the same comment: an employee capped at 1 consecutive day must never be
+// placed on two days in a row by the solver.
+func TestAssignShiftsOptimalRespectsMaxConsecutiveDays(t *testing.T) {
+	s := newOptimizerTestScheduler(2)
+	for _, employee := range s.Employees {
+		employee.MaxConsecutiveDays = 1
+	}
+
+	s.AssignShiftsOptimal(DefaultSchedulerOptions())
+
+	for _, employee := range s.Employees {
+		longestRun := 0
+		current := 0
+		for _, day := range s.Days {
+			if _, worked := employee.GetAssignedShift(day); worked {
+				current++
+				if current > longestRun {
+					longestRun = current
+				}
+			} else {
+				current = 0
+			}
+		}
+		if longestRun > 1 {
+			t.Errorf("%s has a run of %d consecutive days, exceeding their cap of 1", employee.Name, longestRun)
+		}
+	}
+}
+
+// TestAssignShiftsOptimalRespectsRoleRequirements covers the role-based
+// staffing requirement wiring: a location that needs a Manager on Morning
+// must actually be scored against that requirement, not the flat
+// MinEmployeesPerShift constant.
+func TestAssignShiftsOptimalRespectsRoleRequirements(t *testing.T) {
+	s := newOptimizerTestScheduler(0)
+	location := s.getLocation(DefaultLocationID)
+	location.Requirements = map[Shift]map[Role]int{
+		Morning: {"Manager": 1},
+	}
+
+	manager := NewEmployee("Morgan")
+	manager.Skills = []Role{"Manager"}
+	s.Employees = append(s.Employees, manager)
+
+	cashier := NewEmployee("Casey")
+	cashier.Skills = []Role{"Cashier"}
+	s.Employees = append(s.Employees, cashier)
+
+	s.AssignShiftsOptimal(DefaultSchedulerOptions())
+
+	missing := s.missingRoles(location, time.Monday, Morning)
+	if missing["Manager"] != 0 {
+		t.Errorf("expected the Manager requirement to be satisfiable and satisfied, got missing=%v", missing)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// PatternStep is one placement within a ShiftPattern: shift goes on the
+// day DayOffset days after the pattern's start day.
+type PatternStep struct {
+	DayOffset int
+	Shift     Shift
+}
+
+// ShiftPattern describes a multi-day shift that must be placed atomically -
+// the canonical example is a 24-hour Night "stay" that implies a Recovery
+// day immediately after it.
+type ShiftPattern struct {
+	Name  string
+	Steps []PatternStep
+}
+
+// NightStayPattern is the standard 24-hour night shift followed by one
+// mandatory recovery day.
+var NightStayPattern = ShiftPattern{
+	Name: "NightStay",
+	Steps: []PatternStep{
+		{DayOffset: 0, Shift: Night},
+		{DayOffset: 1, Shift: Recovery},
+	},
+}
+
+// RegisterPattern makes a ShiftPattern available for employees to prefer
+// (via Employee.SetPreferredPattern) and for direct placement via AssignPattern.
+func (s *Scheduler) RegisterPattern(pattern ShiftPattern) {
+	s.Patterns = append(s.Patterns, pattern)
+}
+
+// getPattern looks up a registered pattern by name.
+func (s *Scheduler) getPattern(name string) (ShiftPattern, bool) {
+	for _, pattern := range s.Patterns {
+		if pattern.Name == name {
+			return pattern, true
+		}
+	}
+	return ShiftPattern{}, false
+}
+
+// dayAt returns the day DayOffset positions after startDay within s.Days,
+// and false if that falls outside the scheduled week (patterns don't wrap).
+func (s *Scheduler) dayAt(startDay time.Weekday, offset int) (time.Weekday, bool) {
+	startIndex := -1
+	for i, day := range s.Days {
+		if day == startDay {
+			startIndex = i
+			break
+		}
+	}
+	if startIndex == -1 {
+		return 0, false
+	}
+	targetIndex := startIndex + offset
+	if targetIndex < 0 || targetIndex >= len(s.Days) {
+		return 0, false
+	}
+	return s.Days[targetIndex], true
+}
+
+// AssignPattern places every step of pattern for employee, starting on
+// startDay at location. It is all-or-nothing: each step is checked and
+// committed in order against the state left by the steps already committed
+// (so e.g. a Recovery day correctly sees the Night shift placed just before
+// it), and if any step can't be placed (capacity, availability, or the
+// MaxConsecutiveDays cap), every step committed so far is rolled back, no
+// partial pattern is left behind, and a message explains which step blocked it.
+func (s *Scheduler) AssignPattern(employee *Employee, startDay time.Weekday, pattern ShiftPattern, location LocationID) bool {
+	type placement struct {
+		day   time.Weekday
+		shift Shift
+	}
+
+	steps := make([]placement, 0, len(pattern.Steps))
+	for _, step := range pattern.Steps {
+		day, ok := s.dayAt(startDay, step.DayOffset)
+		if !ok {
+			fmt.Printf("⚠️  Pattern %s for %s can't be placed: day offset %d falls outside the scheduled week\n",
+				pattern.Name, employee.Name, step.DayOffset)
+			return false
+		}
+		steps = append(steps, placement{day: day, shift: step.Shift})
+	}
+
+	maxConsecutive := employee.MaxConsecutiveDays
+	if maxConsecutive == 0 {
+		maxConsecutive = DefaultMaxConsecutiveDays
+	}
+
+	committed := make([]placement, 0, len(steps))
+	abort := func(reason string) bool {
+		for i := len(committed) - 1; i >= 0; i-- {
+			s.unassign(employee, committed[i].day, committed[i].shift, location)
+		}
+		fmt.Printf("⚠️  Pattern %s for %s blocked: %s\n", pattern.Name, employee.Name, reason)
+		return false
+	}
+
+	for _, step := range steps {
+		if !s.canAssign(employee, step.day, step.shift, location) {
+			return abort(fmt.Sprintf("can't place %s on %s", step.shift, step.day))
+		}
+		if s.consecutiveDaysIfAssigned(employee, step.day) > maxConsecutive {
+			return abort(fmt.Sprintf("would exceed max consecutive days (%d)", maxConsecutive))
+		}
+		if !s.assign(employee, step.day, step.shift, location) {
+			return abort(fmt.Sprintf("could not assign %s on %s", step.shift, step.day))
+		}
+		committed = append(committed, step)
+	}
+
+	return true
+}
+
+// consecutiveDaysIfAssigned scans s.Days in week order and returns the
+// longest run of consecutive worked days that includes candidateDay,
+// treating candidateDay itself as worked regardless of its current state.
+func (s *Scheduler) consecutiveDaysIfAssigned(employee *Employee, candidateDay time.Weekday) int {
+	longest, current := 0, 0
+	for _, day := range s.Days {
+		worked := day == candidateDay
+		if !worked {
+			_, worked = employee.GetAssignedShift(day)
+		}
+		if worked {
+			current++
+			if current > longest {
+				longest = current
+			}
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
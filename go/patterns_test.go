@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssignPatternSuccessCommitsAllSteps(t *testing.T) {
+	s := NewScheduler()
+	s.RegisterPattern(NightStayPattern)
+
+	employee := NewEmployee("Alex")
+	s.Employees = append(s.Employees, employee)
+
+	if ok := s.AssignPattern(employee, time.Monday, NightStayPattern, DefaultLocationID); !ok {
+		t.Fatalf("expected pattern placement to succeed")
+	}
+
+	if shift, _ := employee.GetAssignedShift(time.Monday); shift != Night {
+		t.Errorf("expected Monday = Night, got %s", shift)
+	}
+	if shift, _ := employee.GetAssignedShift(time.Tuesday); shift != Recovery {
+		t.Errorf("expected Tuesday = Recovery, got %s", shift)
+	}
+	if employee.DaysWorked != 2 {
+		t.Errorf("expected DaysWorked = 2, got %d", employee.DaysWorked)
+	}
+	if len(s.Schedule[DefaultLocationID][time.Monday][Night]) != 1 {
+		t.Errorf("expected Night grid entry for Monday")
+	}
+	if len(s.Schedule[DefaultLocationID][time.Tuesday][Recovery]) != 1 {
+		t.Errorf("expected Recovery grid entry for Tuesday")
+	}
+}
+
+// TestAssignPatternRollsBackOnLaterStepFailure covers the bug where a
+// pattern's first step was committed even though a later step failed,
+// leaving a Night shift with no matching Recovery day. A MaxConsecutiveDays
+// of 1 guarantees the Recovery step (which requires two consecutive worked
+// days) can never be placed, so the whole pattern must land as a no-op.
+func TestAssignPatternRollsBackOnLaterStepFailure(t *testing.T) {
+	s := NewScheduler()
+	s.RegisterPattern(NightStayPattern)
+
+	employee := NewEmployee("Jordan")
+	employee.MaxConsecutiveDays = 1
+	s.Employees = append(s.Employees, employee)
+
+	if ok := s.AssignPattern(employee, time.Monday, NightStayPattern, DefaultLocationID); ok {
+		t.Fatalf("expected pattern placement to fail when it would exceed MaxConsecutiveDays")
+	}
+
+	if _, worked := employee.GetAssignedShift(time.Monday); worked {
+		t.Errorf("Night step should have been rolled back, but Monday is still assigned")
+	}
+	if employee.DaysWorked != 0 {
+		t.Errorf("expected DaysWorked = 0 after rollback, got %d", employee.DaysWorked)
+	}
+	if len(s.Schedule[DefaultLocationID][time.Monday][Night]) != 0 {
+		t.Errorf("expected schedule grid to have no Night assignment after rollback")
+	}
+}
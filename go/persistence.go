@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TimeRange is a clock-time window within a single day (as an offset from
+// midnight), used to map an abstract Shift onto concrete start/end times
+// for calendar export. End may exceed 24h for shifts that cross midnight.
+type TimeRange struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// DefaultShiftTimes gives every built-in Shift a reasonable real-world
+// window; callers can override any subset via SchedulerOptions.ShiftTimes.
+func DefaultShiftTimes() map[Shift]TimeRange {
+	return map[Shift]TimeRange{
+		Morning:   {Start: 6 * time.Hour, End: 14 * time.Hour},
+		Afternoon: {Start: 14 * time.Hour, End: 18 * time.Hour},
+		Evening:   {Start: 18 * time.Hour, End: 22 * time.Hour},
+		Night:     {Start: 22 * time.Hour, End: 46 * time.Hour}, // 24h stay starting at 22:00
+	}
+}
+
+// scheduleSnapshot is the on-disk shape for SaveJSON/LoadJSON: everything
+// needed to recreate a Scheduler's employees, locations and current
+// schedule grid, but not the fixed Days/Shifts scaffolding NewScheduler
+// already sets up.
+type scheduleSnapshot struct {
+	Employees []*Employee
+	Locations []*Location
+	Schedule  map[LocationID]map[time.Weekday]map[Shift][]string
+	Patterns  []ShiftPattern
+}
+
+// SaveJSON writes the scheduler's employees, locations, patterns and
+// current schedule grid to path as JSON.
+func (s *Scheduler) SaveJSON(path string) error {
+	snapshot := scheduleSnapshot{
+		Employees: s.Employees,
+		Locations: s.Locations,
+		Schedule:  s.Schedule,
+		Patterns:  s.Patterns,
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadJSON replaces the scheduler's employees, locations, patterns and
+// schedule grid with the contents of a file previously written by SaveJSON.
+// Days and Shifts are left untouched since they're fixed scaffolding, not
+// part of the saved state.
+func (s *Scheduler) LoadJSON(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshot scheduleSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	s.Employees = snapshot.Employees
+	s.Locations = snapshot.Locations
+	s.Schedule = snapshot.Schedule
+	s.Patterns = snapshot.Patterns
+	return nil
+}
+
+// ExportCSV writes one row per (location, day, shift, employee) assignment,
+// suitable for spreadsheet workflows.
+func (s *Scheduler) ExportCSV(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Location", "Day", "Shift", "Employee"}); err != nil {
+		return err
+	}
+
+	for _, location := range s.Locations {
+		for _, day := range s.Days {
+			for _, shift := range s.allShifts() {
+				for _, name := range s.Schedule[location.ID][day][shift] {
+					if err := writer.Write([]string{location.Name, day.String(), string(shift), name}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return writer.Error()
+}
+
+// ExportICS writes a standards-compliant iCalendar file with one VEVENT per
+// (employee, day, shift) assignment, anchored to real dates via weekStart
+// (the calendar date of s.Days[0], typically a Monday). Each Shift is
+// translated to a concrete UTC time range via shiftTimes, falling back to
+// DefaultShiftTimes for any shift not present in it. Shifts with no
+// configured time range (e.g. Recovery, which has no work to calendar) are
+// skipped.
+func (s *Scheduler) ExportICS(path string, weekStart time.Time, shiftTimes map[Shift]TimeRange) error {
+	if shiftTimes == nil {
+		shiftTimes = DefaultShiftTimes()
+	}
+
+	dayOffset := make(map[time.Weekday]int, len(s.Days))
+	for i, day := range s.Days {
+		dayOffset[day] = i
+	}
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//Employee Scheduling System//EN\r\n")
+
+	uid := 0
+	for _, location := range s.Locations {
+		for _, day := range s.Days {
+			for _, shift := range s.allShifts() {
+				window, ok := shiftTimes[shift]
+				if !ok {
+					continue
+				}
+
+				dayStart := weekStart.AddDate(0, 0, dayOffset[day])
+				start := dayStart.Add(window.Start)
+				end := dayStart.Add(window.End)
+
+				for _, name := range s.Schedule[location.ID][day][shift] {
+					uid++
+					b.WriteString("BEGIN:VEVENT\r\n")
+					fmt.Fprintf(&b, "UID:%d-%s@employee-scheduling-system\r\n", uid, location.ID)
+					fmt.Fprintf(&b, "DTSTART:%s\r\n", formatICSTime(start))
+					fmt.Fprintf(&b, "DTEND:%s\r\n", formatICSTime(end))
+					fmt.Fprintf(&b, "SUMMARY:%s - %s shift (%s)\r\n", name, shift, location.Name)
+					b.WriteString("END:VEVENT\r\n")
+				}
+			}
+		}
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// formatICSTime renders t in iCalendar's UTC "basic" format.
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
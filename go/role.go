@@ -0,0 +1,10 @@
+package main
+
+// Role identifies a job function an employee can be scheduled for within a
+// shift, e.g. "Manager" or "Cashier".
+type Role string
+
+// DefaultRole is used for shifts with no configured Requirements and for
+// employees with no configured Skills, so the system keeps working exactly
+// as it did before roles existed unless a caller opts in.
+const DefaultRole Role = "Staff"
@@ -5,20 +5,41 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// formatMissingRoles renders a role-gap map as "1 Manager, 2 Cashier",
+// sorted by role name so grid output is stable across runs.
+func formatMissingRoles(missing map[Role]int) string {
+	roles := make([]string, 0, len(missing))
+	for role := range missing {
+		roles = append(roles, string(role))
+	}
+	sort.Strings(roles)
+
+	parts := make([]string, len(roles))
+	for i, role := range roles {
+		parts[i] = fmt.Sprintf("%d %s", missing[Role(role)], role)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // Scheduler manages the overall employee scheduling system
 type Scheduler struct {
-	Employees []*Employee                         // List of all employees
-	Schedule  map[time.Weekday]map[Shift][]string // day -> shift -> employee names
-	Days      []time.Weekday                      // Days of the week
-	Shifts    []Shift                             // Available shifts
+	Employees  []*Employee                                        // List of all employees
+	Locations  []*Location                                        // Sites being scheduled; starts with DefaultLocationID
+	Schedule   map[LocationID]map[time.Weekday]map[Shift][]string // location -> day -> shift -> employee names
+	Days       []time.Weekday                                     // Days of the week
+	Shifts     []Shift                                            // Available shifts
+	Patterns   []ShiftPattern                                     // Registered multi-day shift patterns
+	ShiftTimes map[Shift]TimeRange                                // Clock times set by the last AssignShiftsOptimal call, if any; nil until then
 }
 
-// NewScheduler creates a new scheduler with empty schedule
+// NewScheduler creates a new scheduler with empty schedule and a single
+// default location, so single-site callers need no extra setup.
 func NewScheduler() *Scheduler {
 	days := []time.Weekday{
 		time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
@@ -26,21 +47,75 @@ func NewScheduler() *Scheduler {
 	}
 	shifts := []Shift{Morning, Afternoon, Evening}
 
-	// Initialize empty schedule for all days and shifts
-	schedule := make(map[time.Weekday]map[Shift][]string)
-	for _, day := range days {
-		schedule[day] = make(map[Shift][]string)
-		for _, shift := range shifts {
-			schedule[day][shift] = []string{}
-		}
-	}
-
-	return &Scheduler{
+	s := &Scheduler{
 		Employees: []*Employee{},
-		Schedule:  schedule,
+		Locations: []*Location{},
+		Schedule:  make(map[LocationID]map[time.Weekday]map[Shift][]string),
 		Days:      days,
 		Shifts:    shifts,
 	}
+	s.AddLocation(NewLocation(DefaultLocationID, "Default", MinEmployeesPerShift, MaxEmployeesPerShift))
+	return s
+}
+
+// AddLocation registers a new site to be scheduled and allocates its empty
+// schedule grid.
+func (s *Scheduler) AddLocation(location *Location) {
+	s.Locations = append(s.Locations, location)
+	grid := make(map[time.Weekday]map[Shift][]string)
+	for _, day := range s.Days {
+		grid[day] = make(map[Shift][]string)
+		for _, shift := range s.Shifts {
+			grid[day][shift] = []string{}
+		}
+	}
+	s.Schedule[location.ID] = grid
+}
+
+// allShifts returns every shift the scheduler might place: the selectable
+// s.Shifts plus any shift referenced by a registered ShiftPattern (e.g.
+// Night, Recovery). The grid, footer stats and exports all iterate this
+// instead of s.Shifts so pattern placements aren't silently dropped from
+// them.
+func (s *Scheduler) allShifts() []Shift {
+	seen := make(map[Shift]bool, len(s.Shifts))
+	all := make([]Shift, 0, len(s.Shifts))
+	for _, shift := range s.Shifts {
+		seen[shift] = true
+		all = append(all, shift)
+	}
+	for _, pattern := range s.Patterns {
+		for _, step := range pattern.Steps {
+			if !seen[step.Shift] {
+				seen[step.Shift] = true
+				all = append(all, step.Shift)
+			}
+		}
+	}
+	return all
+}
+
+// isSelectableShift reports whether shift is one of s.Shifts - i.e. one
+// employees can be given as a plain preference and locations configure
+// staffing requirements for, as opposed to a pattern-only shift like Night
+// or Recovery.
+func (s *Scheduler) isSelectableShift(shift Shift) bool {
+	for _, candidate := range s.Shifts {
+		if candidate == shift {
+			return true
+		}
+	}
+	return false
+}
+
+// getLocation looks up a registered location by ID.
+func (s *Scheduler) getLocation(id LocationID) *Location {
+	for _, loc := range s.Locations {
+		if loc.ID == id {
+			return loc
+		}
+	}
+	return nil
 }
 
 // AddEmployee interactively adds a new employee with their shift preferences
@@ -97,6 +172,60 @@ func (s *Scheduler) AddEmployee() {
 		}
 	}
 
+	// Collect skills so role-based staffing requirements can place this employee
+	fmt.Println("\n🛠️  Skills (comma-separated roles, blank = general staff, e.g. Manager, Cashier)")
+	fmt.Print("Skills: ")
+	scanner.Scan()
+	skillsInput := strings.TrimSpace(scanner.Text())
+	if skillsInput != "" {
+		for _, token := range strings.Split(skillsInput, ",") {
+			if role := strings.TrimSpace(token); role != "" {
+				employee.Skills = append(employee.Skills, Role(role))
+			}
+		}
+		fmt.Printf("   ✅ Skills set: %s\n", skillsInput)
+	}
+
+	// Collect preferred locations if more than the default site is registered
+	if len(s.Locations) > 1 {
+		fmt.Println("\n🏬 Preferred location (first = primary, blank = any)")
+		for i, loc := range s.Locations {
+			fmt.Printf("   %d=%s\n", i, loc.Name)
+		}
+		fmt.Print("Enter comma-separated location numbers: ")
+		scanner.Scan()
+		input := strings.TrimSpace(scanner.Text())
+		if input != "" {
+			for _, token := range strings.Split(input, ",") {
+				index, err := strconv.Atoi(strings.TrimSpace(token))
+				if err != nil || index < 0 || index >= len(s.Locations) {
+					continue
+				}
+				employee.PreferredLocations = append(employee.PreferredLocations, s.Locations[index].ID)
+			}
+		}
+
+		// A penalty only means anything for a non-primary location, so only
+		// ask about the ones beyond the first (primary, penalty-free) choice.
+		if len(employee.PreferredLocations) > 1 {
+			fmt.Println("💰 Penalty for being scheduled at a non-primary location (blank = 0)")
+			for _, locationID := range employee.PreferredLocations[1:] {
+				fmt.Printf("   %s penalty: ", locationID)
+				scanner.Scan()
+				penaltyInput := strings.TrimSpace(scanner.Text())
+				if penaltyInput == "" {
+					continue
+				}
+				penalty, err := strconv.ParseFloat(penaltyInput, 64)
+				if err != nil || penalty < 0 {
+					fmt.Println("   ❌ Invalid penalty, skipping.")
+					continue
+				}
+				employee.LocationPenalty[locationID] = penalty
+			}
+		}
+	}
+
 	s.Employees = append(s.Employees, employee)
 	fmt.Printf("\n🎉 Employee %s added successfully!\n", name)
 	fmt.Printf("📊 Total employees: %d\n\n", len(s.Employees))
@@ -110,7 +239,9 @@ func (s *Scheduler) PrintSchedule() {
 	}
 
 	s.printScheduleHeader()
-	s.printWeeklyGrid()
+	for _, location := range s.Locations {
+		s.printLocationGrid(location)
+	}
 	s.printScheduleFooter()
 	s.printEmployeeSummaries()
 }
@@ -122,14 +253,15 @@ func (s *Scheduler) printScheduleHeader() {
 	fmt.Println(strings.Repeat("═", 90))
 }
 
-// printWeeklyGrid displays the main schedule in a clean tabular format
-func (s *Scheduler) printWeeklyGrid() {
+// printLocationGrid displays one location's weekly schedule in a clean tabular format
+func (s *Scheduler) printLocationGrid(location *Location) {
+	fmt.Printf("\n🏬 %s\n", location.Name)
 	for _, day := range s.Days {
 		fmt.Printf("\n📅 %s\n", day)
 		fmt.Println(strings.Repeat("─", 85))
 
-		for _, shift := range s.Shifts {
-			employees := s.Schedule[day][shift]
+		for _, shift := range s.allShifts() {
+			employees := s.Schedule[location.ID][day][shift]
 			staffCount := len(employees)
 
 			// Format shift name with emoji
@@ -138,48 +270,57 @@ func (s *Scheduler) printWeeklyGrid() {
 
 			if staffCount == 0 {
 				fmt.Printf("%-50s", "No employees assigned")
-				if staffCount < MinEmployeesPerShift {
-					fmt.Printf(" 🚨 UNDERSTAFFED (need %d)", MinEmployeesPerShift)
-				}
 			} else {
 				employeeList := strings.Join(employees, ", ")
 				if len(employeeList) > 45 {
 					employeeList = employeeList[:42] + "..."
 				}
 				fmt.Printf("%-50s", employeeList)
+			}
 
-				// Status indicator
-				if staffCount < MinEmployeesPerShift {
-					fmt.Printf(" 🚨 UNDERSTAFFED (%d/%d)", staffCount, MinEmployeesPerShift)
-				} else if staffCount >= MinEmployeesPerShift && staffCount < MaxEmployeesPerShift {
-					fmt.Printf(" ✅ STAFFED (%d/%d)", staffCount, MaxEmployeesPerShift)
+			// Pattern-only shifts (Night, Recovery) have no configured
+			// staffing requirement to measure against - they're shown for
+			// visibility, not held to a minimum/maximum headcount.
+			if s.isSelectableShift(shift) {
+				missing := s.missingRoles(location, day, shift)
+				if len(missing) > 0 {
+					fmt.Printf(" 🚨 NEEDS %s", formatMissingRoles(missing))
+				} else if staffCount < location.MaxPerShift {
+					fmt.Printf(" ✅ STAFFED (%d/%d)", staffCount, location.MaxPerShift)
 				} else {
-					fmt.Printf(" 🏆 FULL (%d/%d)", staffCount, MaxEmployeesPerShift)
+					fmt.Printf(" 🏆 FULL (%d/%d)", staffCount, location.MaxPerShift)
 				}
+			} else if staffCount > 0 {
+				fmt.Printf(" 📌 PATTERN")
 			}
 			fmt.Println()
 		}
 	}
 }
 
-// printScheduleFooter displays summary statistics
+// printScheduleFooter displays summary statistics across all locations
 func (s *Scheduler) printScheduleFooter() {
 	fmt.Println("\n" + strings.Repeat("═", 90))
 
-	totalShifts := len(s.Days) * len(s.Shifts)
+	totalShifts := len(s.Locations) * len(s.Days) * len(s.Shifts)
 	staffedShifts := 0
 	fullShifts := 0
 	totalAssignments := 0
 
-	for _, day := range s.Days {
-		for _, shift := range s.Shifts {
-			count := len(s.Schedule[day][shift])
-			totalAssignments += count
-			if count >= MinEmployeesPerShift {
-				staffedShifts++
-			}
-			if count == MaxEmployeesPerShift {
-				fullShifts++
+	for _, location := range s.Locations {
+		for _, day := range s.Days {
+			for _, shift := range s.allShifts() {
+				count := len(s.Schedule[location.ID][day][shift])
+				totalAssignments += count
+				if !s.isSelectableShift(shift) {
+					continue
+				}
+				if len(s.missingRoles(location, day, shift)) == 0 {
+					staffedShifts++
+				}
+				if count == location.MaxPerShift {
+					fullShifts++
+				}
 			}
 		}
 	}
@@ -214,6 +355,10 @@ func getShiftIcon(shift Shift) string {
 		return "☀️"
 	case Evening:
 		return "🌙"
+	case Night:
+		return "🌃"
+	case Recovery:
+		return "🛌"
 	default:
 		return "⏰"
 	}
@@ -252,12 +397,37 @@ func (s *Scheduler) resetSchedules() {
 		employee.ResetSchedule()
 	}
 
-	// Clear all shifts in the main schedule
-	for _, day := range s.Days {
-		for _, shift := range s.Shifts {
-			s.Schedule[day][shift] = []string{}
+	// Clear all shifts in every location's schedule grid
+	for _, location := range s.Locations {
+		for _, day := range s.Days {
+			for _, shift := range s.Shifts {
+				s.Schedule[location.ID][day][shift] = []string{}
+			}
+		}
+	}
+}
+
+// locationsFor returns the locations to try for an employee, cheapest first:
+// their PreferredLocations if they set any, otherwise every registered
+// location (so employees with no preference can be placed anywhere), ordered
+// by PenaltyFor so their primary location (penalty 0) and any other
+// locations they haven't been assigned a penalty for are tried before ones
+// they pay a configured cost to be borrowed to.
+func (s *Scheduler) locationsFor(employee *Employee) []LocationID {
+	var locations []LocationID
+	if len(employee.PreferredLocations) > 0 {
+		locations = append([]LocationID(nil), employee.PreferredLocations...)
+	} else {
+		locations = make([]LocationID, len(s.Locations))
+		for i, loc := range s.Locations {
+			locations[i] = loc.ID
 		}
 	}
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		return employee.PenaltyFor(locations[i]) < employee.PenaltyFor(locations[j])
+	})
+	return locations
 }
 
 // assignPreferredShifts attempts to assign each employee to their preferred shifts,
@@ -270,12 +440,36 @@ func (s *Scheduler) assignPreferredShifts() {
 				break
 			}
 
+			// A preferred pattern (e.g. a night shift + recovery day) takes
+			// priority over a plain shift preference and is placed atomically.
+			if patternName, hasPattern := employee.PreferredPattern[day]; hasPattern {
+				if pattern, found := s.getPattern(patternName); found {
+					placed := false
+					for _, location := range s.locationsFor(employee) {
+						if s.AssignPattern(employee, day, pattern, location) {
+							placed = true
+							break
+						}
+					}
+					if !placed {
+						fmt.Printf("⚠️  Could not place pattern %s for %s starting %s\n", patternName, employee.Name, day)
+					}
+				}
+				continue
+			}
+
 			// Check if employee has a preference for this day
 			if preferredShift, hasPreference := employee.GetPreference(day); hasPreference {
-				if s.canAssign(employee, day, preferredShift) {
-					s.assign(employee, day, preferredShift)
-				} else {
-					// Preferred shift is full or employee can't work - try to resolve conflict
+				assigned := false
+				for _, location := range s.locationsFor(employee) {
+					if s.canAssign(employee, day, preferredShift, location) {
+						s.assign(employee, day, preferredShift, location)
+						assigned = true
+						break
+					}
+				}
+				if !assigned {
+					// Preferred shift is full everywhere or employee can't work - try to resolve conflict
 					s.resolveConflict(employee, day, preferredShift)
 				}
 			}
@@ -284,15 +478,21 @@ func (s *Scheduler) assignPreferredShifts() {
 }
 
 // resolveConflict attempts to find alternative assignments when an employee's preferred shift is unavailable.
-// Strategy: 1) Try other shifts same day, 2) Try preferred shift other days, 3) Try any shift other days
+// Strategy: 1) Try other shifts same day, 2) Try preferred shift other days, 3) Try any shift other days.
+// Each attempt is tried at every location available to the employee, primary location first.
 func (s *Scheduler) resolveConflict(employee *Employee, preferredDay time.Weekday, preferredShift Shift) {
+	locations := s.locationsFor(employee)
+
 	// Strategy 1: Try alternative shifts on the same day
 	for _, otherShift := range s.Shifts {
-		if otherShift != preferredShift {
-			if s.canAssign(employee, preferredDay, otherShift) {
-				s.assign(employee, preferredDay, otherShift)
-				fmt.Printf("🔄 Conflict resolved: %s → %s %s (preferred shift full)\n",
-					employee.Name, preferredDay, otherShift)
+		if otherShift == preferredShift {
+			continue
+		}
+		for _, location := range locations {
+			if s.canAssign(employee, preferredDay, otherShift, location) {
+				s.assign(employee, preferredDay, otherShift, location)
+				fmt.Printf("🔄 Conflict resolved: %s → %s %s @ %s (preferred shift full)\n",
+					employee.Name, preferredDay, otherShift, location)
 				return
 			}
 		}
@@ -305,20 +505,24 @@ func (s *Scheduler) resolveConflict(employee *Employee, preferredDay time.Weekda
 		}
 
 		// Try preferred shift on this alternative day
-		if s.canAssign(employee, otherDay, preferredShift) {
-			s.assign(employee, otherDay, preferredShift)
-			fmt.Printf("🔄 Conflict resolved: %s → %s %s (moved to different day)\n",
-				employee.Name, otherDay, preferredShift)
-			return
+		for _, location := range locations {
+			if s.canAssign(employee, otherDay, preferredShift, location) {
+				s.assign(employee, otherDay, preferredShift, location)
+				fmt.Printf("🔄 Conflict resolved: %s → %s %s @ %s (moved to different day)\n",
+					employee.Name, otherDay, preferredShift, location)
+				return
+			}
 		}
 
 		// Try any available shift on this alternative day
 		for _, otherShift := range s.Shifts {
-			if s.canAssign(employee, otherDay, otherShift) {
-				s.assign(employee, otherDay, otherShift)
-				fmt.Printf("🔄 Conflict resolved: %s → %s %s (alternative assignment)\n",
-					employee.Name, otherDay, otherShift)
-				return
+			for _, location := range locations {
+				if s.canAssign(employee, otherDay, otherShift, location) {
+					s.assign(employee, otherDay, otherShift, location)
+					fmt.Printf("🔄 Conflict resolved: %s → %s %s @ %s (alternative assignment)\n",
+						employee.Name, otherDay, otherShift, location)
+					return
+				}
 			}
 		}
 	}
@@ -327,64 +531,115 @@ func (s *Scheduler) resolveConflict(employee *Employee, preferredDay time.Weekda
 	fmt.Printf("⚠️  Warning: Could not assign %s anywhere (schedule full)\n", employee.Name)
 }
 
-// canAssign checks if an employee can be assigned to a specific day and shift
-// Validates: employee availability, work day limits, and shift capacity limits
-func (s *Scheduler) canAssign(employee *Employee, day time.Weekday, shift Shift) bool {
-	// Check if employee can work this day (not already scheduled, under 5-day limit)
-	if !employee.CanWorkDay(day) {
+// canAssign checks if an employee can be assigned to a specific day, shift and location.
+// Validates: employee availability (which already forbids a second location the
+// same day, since an employee can only hold one shift per day), work day limits,
+// and that location's shift capacity.
+func (s *Scheduler) canAssign(employee *Employee, day time.Weekday, shift Shift, location LocationID) bool {
+	// Check if employee can work this shift (not already scheduled, under 5-day limit, not unavailable)
+	if !employee.CanWorkShift(day, shift) {
+		return false
+	}
+
+	loc := s.getLocation(location)
+	if loc == nil {
+		return false
+	}
+
+	// Check if shift has reached this location's maximum capacity
+	if len(s.Schedule[location][day][shift]) >= loc.MaxPerShift {
 		return false
 	}
 
-	// Check if shift has reached maximum capacity (8 employees per shift)
-	if len(s.Schedule[day][shift]) >= MaxEmployeesPerShift {
+	// Check the employee's consecutive-work-day cap
+	maxConsecutive := employee.MaxConsecutiveDays
+	if maxConsecutive == 0 {
+		maxConsecutive = DefaultMaxConsecutiveDays
+	}
+	if s.consecutiveDaysIfAssigned(employee, day) > maxConsecutive {
 		return false
 	}
 
 	return true
 }
 
-// assign adds an employee to a specific shift and updates both the employee's schedule
-// and the main schedule grid
-func (s *Scheduler) assign(employee *Employee, day time.Weekday, shift Shift) {
-	employee.AssignShift(day, shift)
-	s.Schedule[day][shift] = append(s.Schedule[day][shift], employee.Name)
+// assign adds an employee to a specific shift at a specific location and updates
+// both the employee's schedule and the location's schedule grid. It returns
+// false (and changes nothing) if the employee's side of the assignment fails,
+// e.g. because they were already scheduled elsewhere for that day.
+func (s *Scheduler) assign(employee *Employee, day time.Weekday, shift Shift, location LocationID) bool {
+	if !employee.AssignShift(day, shift, location) {
+		return false
+	}
+	s.Schedule[location][day][shift] = append(s.Schedule[location][day][shift], employee.Name)
+	return true
 }
 
-// ensureMinimumStaffing fills any shifts that don't meet the minimum staffing requirement (2 employees)
-// by randomly assigning available employees who haven't reached their work day limit
+// unassign reverses assign: it removes the employee's shift on day and
+// strips their name from the location's schedule grid. Used to roll back a
+// partially-committed ShiftPattern when a later step fails.
+func (s *Scheduler) unassign(employee *Employee, day time.Weekday, shift Shift, location LocationID) {
+	employee.RemoveShift(day)
+	names := s.Schedule[location][day][shift]
+	for i, name := range names {
+		if name == employee.Name {
+			s.Schedule[location][day][shift] = append(names[:i], names[i+1:]...)
+			break
+		}
+	}
+}
+
+// ensureMinimumStaffing fills any shifts that don't meet each location's minimum staffing
+// requirement by randomly assigning available employees who haven't reached their work day limit
 func (s *Scheduler) ensureMinimumStaffing() {
+	for _, location := range s.Locations {
+		s.ensureMinimumStaffingAt(location)
+	}
+}
+
+// ensureMinimumStaffingAt is the single-location body of ensureMinimumStaffing.
+// Staffing is checked per role: a shift with plenty of Cashiers but no
+// Manager is still understaffed even though its total headcount is fine.
+func (s *Scheduler) ensureMinimumStaffingAt(location *Location) {
 	for _, day := range s.Days {
 		for _, shift := range s.Shifts {
-			currentStaff := len(s.Schedule[day][shift])
-
-			// Check if this shift needs more employees to meet minimum requirement
-			if currentStaff < MinEmployeesPerShift {
-				needed := MinEmployeesPerShift - currentStaff
-				availableEmployees := s.getAvailableEmployees(day)
+			requirements := location.RequirementsFor(shift)
+
+			for role, required := range requirements {
+				// Recomputed fresh for every role (rather than snapshotted
+				// once per shift) so a multi-skilled employee assigned to
+				// fill an earlier role in this loop is already reflected
+				// before the next role's gap is measured.
+				have := s.roleCounts(location.ID, day, shift)[role]
+				if have >= required {
+					continue
+				}
+				needed := required - have
+				candidates := s.employeesWithSkill(role, day)
 
 				// Randomly shuffle available employees for fair distribution
 				rand.Seed(time.Now().UnixNano())
-				rand.Shuffle(len(availableEmployees), func(i, j int) {
-					availableEmployees[i], availableEmployees[j] = availableEmployees[j], availableEmployees[i]
+				rand.Shuffle(len(candidates), func(i, j int) {
+					candidates[i], candidates[j] = candidates[j], candidates[i]
 				})
 
-				// Assign employees until minimum is met or no more employees available
+				// Assign employees until this role's minimum is met or no more candidates available
 				assigned := 0
-				for _, employee := range availableEmployees {
+				for _, employee := range candidates {
 					if assigned >= needed {
 						break
 					}
 
-					if s.canAssign(employee, day, shift) {
-						s.assign(employee, day, shift)
+					if s.canAssign(employee, day, shift, location.ID) {
+						s.assign(employee, day, shift, location.ID)
 						assigned++
 					}
 				}
 
-				// Warn if unable to meet minimum staffing despite trying
+				// Warn if unable to meet this role's minimum despite trying
 				if assigned < needed {
-					fmt.Printf("⚠️  Understaffed: %s %s needs %d more employees (have %d/%d)\n",
-						day, shift, needed-assigned, currentStaff+assigned, MinEmployeesPerShift)
+					fmt.Printf("⚠️  Understaffed: %s %s @ %s needs %d more %s (have %d/%d)\n",
+						day, shift, location.Name, needed-assigned, role, have+assigned, required)
 				}
 			}
 		}
@@ -403,6 +658,53 @@ func (s *Scheduler) getAvailableEmployees(day time.Weekday) []*Employee {
 	return available
 }
 
+// employeesWithSkill narrows getAvailableEmployees down to employees who
+// can fill role.
+func (s *Scheduler) employeesWithSkill(role Role, day time.Weekday) []*Employee {
+	var matching []*Employee
+	for _, employee := range s.getAvailableEmployees(day) {
+		if employee.HasSkill(role) {
+			matching = append(matching, employee)
+		}
+	}
+	return matching
+}
+
+// roleCounts tallies how many employees currently assigned to (location,
+// day, shift) can fill each role they're credited for.
+func (s *Scheduler) roleCounts(location LocationID, day time.Weekday, shift Shift) map[Role]int {
+	counts := make(map[Role]int)
+	for _, name := range s.Schedule[location][day][shift] {
+		employee := s.employeeByName(name)
+		if employee == nil {
+			continue
+		}
+		if len(employee.Skills) == 0 {
+			counts[DefaultRole]++
+			continue
+		}
+		for _, skill := range employee.Skills {
+			counts[skill]++
+		}
+	}
+	return counts
+}
+
+// missingRoles reports, for each role required at (location, day, shift),
+// how many more employees with that skill are still needed.
+func (s *Scheduler) missingRoles(location *Location, day time.Weekday, shift Shift) map[Role]int {
+	requirements := location.RequirementsFor(shift)
+	have := s.roleCounts(location.ID, day, shift)
+
+	missing := make(map[Role]int)
+	for role, required := range requirements {
+		if have[role] < required {
+			missing[role] = required - have[role]
+		}
+	}
+	return missing
+}
+
 // // GenerateRealisticTest creates 20 employees with diverse, realistic shift preferences
 // func (s *Scheduler) GenerateRealisticTest() {
 // 	s.Employees = []*Employee{}
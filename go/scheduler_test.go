@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+// TestLocationsForOrdersByPenalty confirms LocationPenalty actually drives
+// location choice order: the primary location (penalty 0) stays first, and
+// the remaining preferred locations are tried cheapest-penalty-first rather
+// than in whatever order they were listed.
+func TestLocationsForOrdersByPenalty(t *testing.T) {
+	s := NewScheduler()
+	s.AddLocation(NewLocation("branch-b", "Branch B", 1, 5))
+	s.AddLocation(NewLocation("branch-c", "Branch C", 1, 5))
+
+	employee := NewEmployee("Sam")
+	employee.PreferredLocations = []LocationID{DefaultLocationID, "branch-b", "branch-c"}
+	employee.LocationPenalty["branch-b"] = 5
+	employee.LocationPenalty["branch-c"] = 1
+
+	got := s.locationsFor(employee)
+	want := []LocationID{DefaultLocationID, "branch-c", "branch-b"}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected order %v, got %v", want, got)
+			break
+		}
+	}
+}